@@ -5,5 +5,8 @@ import "github.com/kyawmyintthein/orange"
 var App *orange.App
 
 func init() {
-	App = orange.NewApp("Test")
+	var err error
+	if App, err = orange.NewApp("Test"); err != nil {
+		panic(err)
+	}
 }
@@ -6,34 +6,95 @@ import "log"
 var App *orange.App
 var ns_v1 *orange.Router
 var config *orange.Config
+var events = orange.NewBroker(100)
+var hub = orange.NewHub()
+
+type Object struct {
+	Name string `json:"name" validate:"required,min=1,max=64"`
+}
+
+type ObjectList struct {
+	Object string `json:"Object"`
+}
 func main() {
-	App.Start(config.GetString("app.dev.address"))
+	if err := App.Run(config.GetString("app.address")); err != nil {
+		log.Fatalf("Server error %+v \n", err)
+	}
 }
 
 func init() {
-	App = orange.NewApp("Test")
+	var err error
+	if App, err = orange.NewApp("Test"); err != nil {
+		log.Fatalf("Config error %+v \n", err)
+	}
 	log.Printf("Default ENV %+v \n", App.ENV())
 	config = App.AppConfig()	
 	log.Printf("Config %+v \n", config)
 	log.Printf("App Name %+v \n", config.GetString("app.name"))
 	var dbConfig *orange.Config
-	var err error
 	if dbConfig, err = App.NewConfig("db", "", "json"); err != nil{
-		log.Printf("Error " + err.Error())
+		log.Printf("Error %+v \n", err)
+	} else {
+		log.Printf("Config %+v \n", dbConfig)
+		log.Printf("Database %+v \n", dbConfig.GetString("database.name"))
 	}
 
-	log.Printf("Config %+v \n", dbConfig)
-	log.Printf("DEV database %+v \n", dbConfig.GetString("dev.database.name"))
-
+	cors := orange.CORS()
+	App.Use(cors)
 	ns_v1 = App.Namespace("/v1")
-	var objectController = ns_v1.Controller("/objects")
+	ns_v1.Use(cors)
+	ns_v1.NotFound(func(ctx *orange.Context) {
+		ctx.JSON(http.StatusNotFound, map[string]interface{}{"version": "v1", "error": "resource not found"})
+	})
+	var objectController = ns_v1.Controller("/objects").Tag("objects", "Stored objects")
 	objectController.GET("/", func(ctx *orange.Context) {
-		ctx.JSON(http.StatusOK, map[string]interface{}{"Object": "Value"})
+		ctx.JSON(http.StatusOK, ObjectList{Object: "Value"})
 	})
+	objectController.Name("objects.index").Doc().
+		Summary("List objects").
+		Response(http.StatusOK, ObjectList{}, "")
 
 	objectController.GET("/:name", func(ctx *orange.Context) {
 		name := ctx.Param("name")
-		ctx.JSON(http.StatusOK, map[string]interface{}{"name": name})
+		ctx.Negotiate(http.StatusOK, Object{Name: name})
+	})
+	objectController.Name("objects.show").Doc().
+		Summary("Get object").
+		Param("name", "object name").
+		Response(http.StatusOK, Object{}, "")
+
+	objectController.POST("/", func(ctx *orange.Context) {
+		var object Object
+		if err := ctx.Bind(&object); err != nil {
+			ctx.Error(err)
+			return
+		}
+		events.Publish("objects", "created", object)
+		if err := hub.BroadcastJSON("objects", map[string]interface{}{"event": "created", "object": object}); err != nil {
+			log.Printf("Broadcast error %+v \n", err)
+		}
+		ctx.JSON(http.StatusCreated, object)
+	})
+	objectController.Name("objects.create").Doc().
+		Summary("Create object").
+		Body(Object{}).
+		Response(http.StatusCreated, Object{}, "")
+
+	ns_v1.GET("/events/:topic", func(ctx *orange.Context) {
+		events.Stream(ctx, ctx.Param("topic"))
+	})
+
+	ns_v1.WebSocket("/ws", func(ctx *orange.Context, ws *orange.WebSocket) {
+		hub.Join("objects", ws)
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+
+	App.Namespace("/admin").GET("/", func(ctx *orange.Context) {
+		ctx.Render(http.StatusOK, "admin/index", map[string]interface{}{"Name": config.GetString("app.name")})
 	})
 }
 